@@ -36,7 +36,11 @@ const (
 	intTransformerDescription = "Generate integer value in min and max thresholds"
 )
 
-const integerTransformerGenByteLength = 8
+// integerTransformerGenByteLength must cover the widest Sampler's requirement:
+// NormalSampler and ZipfSampler both consume two uint64 halves (16 bytes) to drive
+// Box-Muller / Devroye rejection sampling, so 8 bytes (enough only for UniformSampler)
+// would make every normal/zipf transform fail at runtime.
+const integerTransformerGenByteLength = 16
 
 var integerTransformerParams = []*toolkit.ParameterDefinition{
 	toolkit.MustNewParameterDefinition(
@@ -70,14 +74,53 @@ var integerTransformerParams = []*toolkit.ParameterDefinition{
 		"keep_null",
 		"indicates that NULL values must not be replaced with transformed values",
 	).SetDefaultValue(toolkit.ParamsValue("true")),
+
+	toolkit.MustNewParameterDefinition(
+		"distribution",
+		"distribution to sample values from: uniform, normal, exponential or zipf",
+	).SetDefaultValue(toolkit.ParamsValue("uniform")),
+
+	toolkit.MustNewParameterDefinition(
+		"mean",
+		"mean of the normal distribution (used when distribution = normal)",
+	).SetDefaultValue(toolkit.ParamsValue("0")),
+
+	toolkit.MustNewParameterDefinition(
+		"stddev",
+		"standard deviation of the normal distribution (used when distribution = normal)",
+	).SetDefaultValue(toolkit.ParamsValue("1")),
+
+	toolkit.MustNewParameterDefinition(
+		"rate",
+		"rate (lambda) of the exponential distribution (used when distribution = exponential)",
+	).SetDefaultValue(toolkit.ParamsValue("1")),
+
+	toolkit.MustNewParameterDefinition(
+		"s",
+		"s (skew) parameter of the zipf distribution (used when distribution = zipf)",
+	).SetDefaultValue(toolkit.ParamsValue("1.5")),
+
+	toolkit.MustNewParameterDefinition(
+		"v",
+		"v (offset) parameter of the zipf distribution (used when distribution = zipf)",
+	).SetDefaultValue(toolkit.ParamsValue("1")),
 }
 
+// maxAcceptableRejectionRate is the fraction of out-of-range samples above which
+// validateDistributionAndSetSampler surfaces a validation warning: beyond this point the
+// chosen distribution parameters make the reject-and-redraw loop unreasonably expensive.
+const maxAcceptableRejectionRate = 0.5
+
+// rejectionRateEstimationTrials controls how many synthetic draws EstimateRejectionRate
+// performs to estimate a distribution's rejection rate for a given limiter.
+const rejectionRateEstimationTrials = 10_000
+
 type IntegerTransformer struct {
 	columnName      string
 	keepNull        bool
 	affectedColumns map[int]string
 	columnIdx       int
-	t               transformers.Transformer
+	t               *transformers.Int64Transformer
 	dynamicMode     bool
 	intSize         int
 
@@ -143,6 +186,15 @@ func NewIntegerTransformer(ctx context.Context, driver *toolkit.Driver, paramete
 		return nil, nil, fmt.Errorf("error initializing common int transformer: %w", err)
 	}
 
+	sampler, samplerWarnings, err := validateDistributionAndSetSampler(parameters, limiter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if samplerWarnings.IsFatal() {
+		return nil, samplerWarnings, nil
+	}
+	t.SetSampler(sampler)
+
 	return &IntegerTransformer{
 		columnName:      columnName,
 		keepNull:        keepNull,
@@ -157,7 +209,7 @@ func NewIntegerTransformer(ctx context.Context, driver *toolkit.Driver, paramete
 
 		dynamicMode: dynamicMode,
 		intSize:     intSize,
-	}, nil, nil
+	}, samplerWarnings, nil
 }
 
 func (rit *IntegerTransformer) GetAffectedColumns() map[int]string {
@@ -196,7 +248,7 @@ func (rit *IntegerTransformer) dynamicTransform(ctx context.Context, r *toolkit.
 	if err != nil {
 		return nil, fmt.Errorf("error creating limiter in dynamic mode: %w", err)
 	}
-	ctx = context.WithValue(ctx, "limiter", limiter)
+	ctx = context.WithValue(ctx, transformers.LimiterContextKey, limiter)
 	res, err := rit.t.Transform(ctx, val.Data)
 	if err != nil {
 		return nil, fmt.Errorf("error generating int value: %w", err)
@@ -279,6 +331,66 @@ func limitIsValid(requestedThreshold, minValue, maxValue int64) bool {
 	return requestedThreshold >= minValue || requestedThreshold <= maxValue
 }
 
+// validateDistributionAndSetSampler builds the transformers.Sampler requested via the
+// "distribution" parameter and warns if its parameters would make the transformer reject
+// most of the values it draws.
+func validateDistributionAndSetSampler(
+	parameters map[string]toolkit.Parameterizer, limiter *transformers.Int64Limiter,
+) (transformers.Sampler, toolkit.ValidationWarnings, error) {
+
+	var distribution string
+	if err := parameters["distribution"].Scan(&distribution); err != nil {
+		return nil, nil, fmt.Errorf(`unable to scan "distribution" param: %w`, err)
+	}
+
+	var sampler transformers.Sampler
+	switch distribution {
+	case "", "uniform":
+		sampler = transformers.UniformSampler{}
+	case "normal":
+		var mean, stddev float64
+		if err := parameters["mean"].Scan(&mean); err != nil {
+			return nil, nil, fmt.Errorf(`unable to scan "mean" param: %w`, err)
+		}
+		if err := parameters["stddev"].Scan(&stddev); err != nil {
+			return nil, nil, fmt.Errorf(`unable to scan "stddev" param: %w`, err)
+		}
+		sampler = transformers.NormalSampler{Mean: mean, StdDev: stddev}
+	case "exponential":
+		var rate float64
+		if err := parameters["rate"].Scan(&rate); err != nil {
+			return nil, nil, fmt.Errorf(`unable to scan "rate" param: %w`, err)
+		}
+		sampler = transformers.ExponentialSampler{Rate: rate}
+	case "zipf":
+		var s, v float64
+		if err := parameters["s"].Scan(&s); err != nil {
+			return nil, nil, fmt.Errorf(`unable to scan "s" param: %w`, err)
+		}
+		if err := parameters["v"].Scan(&v); err != nil {
+			return nil, nil, fmt.Errorf(`unable to scan "v" param: %w`, err)
+		}
+		if s <= 1 || v < 1 {
+			return nil, nil, fmt.Errorf(`invalid zipf parameters: "s" must be > 1 and "v" must be >= 1, got s=%v v=%v`, s, v)
+		}
+		sampler = transformers.ZipfSampler{S: s, V: v}
+	default:
+		return nil, nil, fmt.Errorf("unknown distribution %q", distribution)
+	}
+
+	var warns toolkit.ValidationWarnings
+	if rate := transformers.EstimateRejectionRate(sampler, limiter, rejectionRateEstimationTrials); rate > maxAcceptableRejectionRate {
+		warns = append(warns, toolkit.NewValidationWarning().
+			SetMsgf("distribution %q rejects ~%.0f%% of samples for the given min/max: consider widening the range or adjusting its parameters", distribution, rate*100).
+			SetSeverity(toolkit.WarningValidationSeverity).
+			AddMeta("ParameterName", "distribution").
+			AddMeta("ParameterValue", distribution),
+		)
+	}
+
+	return sampler, warns, nil
+}
+
 func validateIntTypeAndSetLimit(
 	size int, requestedMinValue, requestedMaxValue int64,
 ) (limiter *transformers.Int64Limiter, warns toolkit.ValidationWarnings, err error) {
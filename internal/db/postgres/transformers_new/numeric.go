@@ -0,0 +1,398 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers_new
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/greenmaskio/greenmask/internal/db/postgres/transformers/utils"
+	"github.com/greenmaskio/greenmask/internal/generators"
+	"github.com/greenmaskio/greenmask/internal/generators/transformers"
+	"github.com/greenmaskio/greenmask/pkg/toolkit"
+)
+
+const (
+	numericTransformerName        = "Numeric"
+	numericTransformerDescription = "Generate numeric/decimal value in min and max thresholds"
+)
+
+// numericTransformerGenByteLength is how many bytes one Generate call of the registered
+// generator returns: when BigIntLimiter.ByteLength is larger, BigIntTransformer.Transform
+// issues further Generate calls until it has enough bytes.
+const numericTransformerGenByteLength = 32
+
+// defaultNumericScale is the default of the "scale" parameter. The column's declared
+// numeric(precision, scale) is not read, so this default applies to every column for
+// which "scale" is not given.
+const defaultNumericScale = 2
+
+// defaultNumericPrecision bounds the default min/max thresholds (±(10^defaultNumericPrecision-1))
+// used when no "min"/"max" parameter is given, so BigIntLimiter.ByteLength stays small
+// enough to fit within numericTransformerGenByteLength.
+const defaultNumericPrecision = 38
+
+var numericTransformerParams = []*toolkit.ParameterDefinition{
+	toolkit.MustNewParameterDefinition(
+		"column",
+		"column name",
+	).SetIsColumn(
+		toolkit.NewColumnProperties().
+			SetAffected(true).
+			SetAllowedColumnTypes("numeric", "decimal"),
+	).SetRequired(true),
+
+	toolkit.MustNewParameterDefinition(
+		"min",
+		"min threshold of the numeric value",
+	).SetLinkParameter("column").
+		SetDynamicMode(
+			toolkit.NewDynamicModeProperties().
+				SetCompatibleTypes("numeric", "decimal"),
+		),
+
+	toolkit.MustNewParameterDefinition(
+		"max",
+		"max threshold of the numeric value",
+	).SetLinkParameter("column").
+		SetDynamicMode(
+			toolkit.NewDynamicModeProperties().
+				SetCompatibleTypes("numeric", "decimal"),
+		),
+
+	toolkit.MustNewParameterDefinition(
+		"precision",
+		"total number of decimal digits allowed when no min/max is given "+
+			"(toolkit.Column only exposes attlen, not atttypmod, so the column's declared "+
+			"numeric(precision, scale) cannot be read back automatically)",
+	).SetDefaultValue(toolkit.ParamsValue(fmt.Sprintf("%d", defaultNumericPrecision))),
+
+	toolkit.MustNewParameterDefinition(
+		"scale",
+		"decimal scale to use for the generated value",
+	).SetDefaultValue(toolkit.ParamsValue(fmt.Sprintf("%d", defaultNumericScale))),
+
+	toolkit.MustNewParameterDefinition(
+		"keep_null",
+		"indicates that NULL values must not be replaced with transformed values",
+	).SetDefaultValue(toolkit.ParamsValue("true")),
+}
+
+// NumericTransformer generates arbitrary-precision numeric/decimal values. Unlike
+// IntegerTransformer it does not restrict itself to int2/int4/int8 thresholds: min/max are
+// scanned as strings and handled with math/big so columns such as money, wide surrogate
+// keys or high-precision measurements stay in range.
+type NumericTransformer struct {
+	columnName      string
+	keepNull        bool
+	affectedColumns map[int]string
+	columnIdx       int
+	t               *transformers.BigIntTransformer
+	dynamicMode     bool
+	precision       int
+	scale           int
+
+	columnParam   toolkit.Parameterizer
+	maxParam      toolkit.Parameterizer
+	minParam      toolkit.Parameterizer
+	keepNullParam toolkit.Parameterizer
+}
+
+func NewNumericTransformer(ctx context.Context, driver *toolkit.Driver, parameters map[string]toolkit.Parameterizer, g generators.Generator) (utils.Transformer, toolkit.ValidationWarnings, error) {
+
+	var columnName, minVal, maxVal string
+	var keepNull, dynamicMode bool
+	var precision, scale int
+
+	columnParam := parameters["column"]
+	minParam := parameters["min"]
+	maxParam := parameters["max"]
+	precisionParam := parameters["precision"]
+	scaleParam := parameters["scale"]
+	keepNullParam := parameters["keep_null"]
+
+	if minParam.IsDynamic() || maxParam.IsDynamic() {
+		dynamicMode = true
+	}
+
+	if err := columnParam.Scan(&columnName); err != nil {
+		return nil, nil, fmt.Errorf(`unable to scan "column" param: %w`, err)
+	}
+
+	idx, _, ok := driver.GetColumnByName(columnName)
+	if !ok {
+		return nil, nil, fmt.Errorf("column with name %s is not found", columnName)
+	}
+	affectedColumns := make(map[int]string)
+	affectedColumns[idx] = columnName
+
+	// toolkit.Column.Length reports the type's physical attlen, not its atttypmod, and
+	// attlen for numeric/decimal is always -1 regardless of the declared precision/scale.
+	// There is no way to recover numeric(precision, scale) from the driver today, so both
+	// are taken from the "precision"/"scale" parameters rather than the column definition.
+	if err := precisionParam.Scan(&precision); err != nil {
+		return nil, nil, fmt.Errorf(`unable to scan "precision" param: %w`, err)
+	}
+	if err := scaleParam.Scan(&scale); err != nil {
+		return nil, nil, fmt.Errorf(`unable to scan "scale" param: %w`, err)
+	}
+
+	if err := keepNullParam.Scan(&keepNull); err != nil {
+		return nil, nil, fmt.Errorf(`unable to scan "keep_null" param: %w`, err)
+	}
+
+	if !dynamicMode {
+		if err := minParam.Scan(&minVal); err != nil {
+			return nil, nil, fmt.Errorf(`error scanning "min" parameter: %w`, err)
+		}
+		if err := maxParam.Scan(&maxVal); err != nil {
+			return nil, nil, fmt.Errorf(`error scanning "max" parameter: %w`, err)
+		}
+	}
+
+	limiter, limiterWarnings, err := validateNumericTypeAndSetLimit(precision, scale, minVal, maxVal)
+	if err != nil {
+		return nil, nil, err
+	}
+	if limiterWarnings.IsFatal() {
+		return nil, limiterWarnings, nil
+	}
+
+	t, err := transformers.NewBigIntTransformer(g, limiter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error initializing big int transformer: %w", err)
+	}
+
+	return &NumericTransformer{
+		columnName:      columnName,
+		keepNull:        keepNull,
+		affectedColumns: affectedColumns,
+		columnIdx:       idx,
+
+		columnParam:   columnParam,
+		minParam:      minParam,
+		maxParam:      maxParam,
+		keepNullParam: keepNullParam,
+		t:             t,
+
+		dynamicMode: dynamicMode,
+		precision:   precision,
+		scale:       scale,
+	}, nil, nil
+}
+
+func (nt *NumericTransformer) GetAffectedColumns() map[int]string {
+	return nt.affectedColumns
+}
+
+func (nt *NumericTransformer) Init(ctx context.Context) error {
+	return nil
+}
+
+func (nt *NumericTransformer) Done(ctx context.Context) error {
+	return nil
+}
+
+func (nt *NumericTransformer) dynamicTransform(ctx context.Context, r *toolkit.Record) (*toolkit.Record, error) {
+	val, err := r.GetRawColumnValueByIdx(nt.columnIdx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan value: %w", err)
+	}
+	if val.IsNull && nt.keepNull {
+		return r, nil
+	}
+
+	var minVal, maxVal string
+	if err = nt.minParam.Scan(&minVal); err != nil {
+		return nil, fmt.Errorf(`unable to scan "min" param: %w`, err)
+	}
+	if err = nt.maxParam.Scan(&maxVal); err != nil {
+		return nil, fmt.Errorf(`unable to scan "max" param: %w`, err)
+	}
+
+	limiter, warns, err := validateNumericTypeAndSetLimit(nt.precision, nt.scale, minVal, maxVal)
+	if err != nil {
+		return nil, fmt.Errorf("error creating limiter in dynamic mode: %w", err)
+	}
+	if warns.IsFatal() {
+		return nil, fmt.Errorf("error creating limiter in dynamic mode: %v", warns)
+	}
+	ctx = context.WithValue(ctx, transformers.LimiterContextKey, limiter)
+
+	res, err := nt.t.Transform(ctx, val.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating numeric value: %w", err)
+	}
+
+	if err = r.SetRawColumnValueByIdx(nt.columnIdx, toolkit.NewRawValue(scaleToDecimalString(res, nt.scale), false)); err != nil {
+		return nil, fmt.Errorf("unable to set new value: %w", err)
+	}
+	return r, nil
+}
+
+func (nt *NumericTransformer) staticTransform(ctx context.Context, r *toolkit.Record) (*toolkit.Record, error) {
+	val, err := r.GetRawColumnValueByIdx(nt.columnIdx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan value: %w", err)
+	}
+	if val.IsNull && nt.keepNull {
+		return r, nil
+	}
+
+	res, err := nt.t.Transform(ctx, val.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error generating numeric value: %w", err)
+	}
+
+	if err = r.SetRawColumnValueByIdx(nt.columnIdx, toolkit.NewRawValue(scaleToDecimalString(res, nt.scale), false)); err != nil {
+		return nil, fmt.Errorf("unable to set new value: %w", err)
+	}
+	return r, nil
+}
+
+func (nt *NumericTransformer) Transform(ctx context.Context, r *toolkit.Record) (*toolkit.Record, error) {
+	if nt.dynamicMode {
+		return nt.dynamicTransform(ctx, r)
+	}
+	return nt.staticTransform(ctx, r)
+}
+
+// scaleToDecimalString renders a scaled big.Int (produced by the BigIntLimiter working in
+// units of 10^-scale) back into a plain decimal string literal for the numeric column.
+func scaleToDecimalString(scaledValue []byte, scale int) []byte {
+	if scale <= 0 {
+		return scaledValue
+	}
+
+	n := new(big.Int)
+	if _, ok := n.SetString(string(scaledValue), 10); !ok {
+		return scaledValue
+	}
+
+	neg := n.Sign() < 0
+	n.Abs(n)
+	digits := n.String()
+	for len(digits) <= scale {
+		digits = "0" + digits
+	}
+	intPart, fracPart := digits[:len(digits)-scale], digits[len(digits)-scale:]
+
+	res := intPart + "." + fracPart
+	if neg {
+		res = "-" + res
+	}
+	return []byte(res)
+}
+
+func validateNumericTypeAndSetLimit(
+	precision, scale int, requestedMinValue, requestedMaxValue string,
+) (limiter *transformers.BigIntLimiter, warns toolkit.ValidationWarnings, err error) {
+
+	scaleFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+
+	// Bound the default range to ±(10^precision-1) rather than an arbitrary huge value:
+	// BigIntLimiter.ByteLength grows with the range, and an unbounded default would ask the
+	// generator for more bytes than numericTransformerGenByteLength provides.
+	defaultBound := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	defaultBound.Sub(defaultBound, big.NewInt(1))
+	minValue, maxValue := new(big.Int).Neg(defaultBound), defaultBound
+
+	if requestedMinValue != "" {
+		parsed, ok := parseScaledDecimal(requestedMinValue, scaleFactor)
+		if !ok {
+			warns = append(warns, toolkit.NewValidationWarning().
+				SetMsgf("unable to parse requested min value %q as a numeric literal", requestedMinValue).
+				SetSeverity(toolkit.ErrorValidationSeverity).
+				AddMeta("ParameterName", "min").
+				AddMeta("ParameterValue", requestedMinValue),
+			)
+		} else {
+			minValue = parsed
+		}
+	}
+
+	if requestedMaxValue != "" {
+		parsed, ok := parseScaledDecimal(requestedMaxValue, scaleFactor)
+		if !ok {
+			warns = append(warns, toolkit.NewValidationWarning().
+				SetMsgf("unable to parse requested max value %q as a numeric literal", requestedMaxValue).
+				SetSeverity(toolkit.ErrorValidationSeverity).
+				AddMeta("ParameterName", "max").
+				AddMeta("ParameterValue", requestedMaxValue),
+			)
+		} else {
+			maxValue = parsed
+		}
+	}
+
+	if warns.IsFatal() {
+		return nil, warns, nil
+	}
+
+	limiter, err = transformers.NewBigIntLimiter(minValue, maxValue)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating big int limiter: %w", err)
+	}
+
+	return limiter, nil, nil
+}
+
+// parseScaledDecimal parses a decimal literal (e.g. "123.45") and returns it as a big.Int
+// scaled by scaleFactor (10^scale), i.e. the fixed-point representation used internally.
+// It goes through big.Rat rather than big.Float: binary floating point cannot represent
+// most decimal fractions exactly, so a big.Float-based conversion truncates some scaled
+// values down by one (e.g. "0.05" at scale 2, or "123.45"). big.Rat keeps the value exact
+// until the final rounding step below.
+func parseScaledDecimal(value string, scaleFactor *big.Int) (*big.Int, bool) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, false
+	}
+	r.Mul(r, new(big.Rat).SetInt(scaleFactor))
+	return roundRatToInt(r), true
+}
+
+// roundRatToInt rounds r to the nearest integer, ties away from zero. Only a value with
+// more fractional digits than scale leaves a remainder here; an exact scale match divides
+// evenly.
+func roundRatToInt(r *big.Rat) *big.Int {
+	quo, rem := new(big.Int), new(big.Int)
+	quo.QuoRem(r.Num(), r.Denom(), rem)
+
+	if rem.Sign() != 0 {
+		doubledRem := new(big.Int).Lsh(new(big.Int).Abs(rem), 1)
+		if doubledRem.Cmp(r.Denom()) >= 0 {
+			if rem.Sign() < 0 {
+				quo.Sub(quo, big.NewInt(1))
+			} else {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+	return quo
+}
+
+func init() {
+
+	registerRandomAndDeterministicTransformer(
+		utils.DefaultTransformerRegistry,
+		numericTransformerName,
+		numericTransformerDescription,
+		NewNumericTransformer,
+		numericTransformerParams,
+		numericTransformerGenByteLength,
+	)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers_new
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestValidateNumericTypeAndSetLimit_DefaultRange ensures that, with no min/max supplied,
+// the default range is bounded by the requested precision rather than an arbitrary huge
+// value, so the resulting limiter's byte requirement stays sane.
+func TestValidateNumericTypeAndSetLimit_DefaultRange(t *testing.T) {
+	limiter, warns, err := validateNumericTypeAndSetLimit(defaultNumericPrecision, defaultNumericScale, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if warns.IsFatal() {
+		t.Fatalf("unexpected fatal warnings: %v", warns)
+	}
+
+	if limiter.ByteLength() > numericTransformerGenByteLength {
+		t.Fatalf("default-range limiter needs %d bytes, more than the registered generator length %d",
+			limiter.ByteLength(), numericTransformerGenByteLength)
+	}
+}
+
+// TestParseScaledDecimal_Fractional exercises decimal inputs that a big.Float-based
+// conversion gets wrong due to binary floating-point imprecision: "0.05" at scale 2 used
+// to truncate down to 4 instead of 5.
+func TestParseScaledDecimal_Fractional(t *testing.T) {
+	tests := []struct {
+		value string
+		scale int
+		want  int64
+	}{
+		{"0.05", 2, 5},
+		{"123.45", 2, 12345},
+		{"-0.05", 2, -5},
+		{"0.125", 2, 13}, // more fractional digits than scale: rounds half away from zero
+		{"1", 2, 100},
+	}
+
+	for _, tt := range tests {
+		scaleFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tt.scale)), nil)
+		got, ok := parseScaledDecimal(tt.value, scaleFactor)
+		if !ok {
+			t.Fatalf("parseScaledDecimal(%q, %d) failed to parse", tt.value, tt.scale)
+		}
+		if got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("parseScaledDecimal(%q, %d) = %s, want %d", tt.value, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestScaleToDecimalString(t *testing.T) {
+	tests := []struct {
+		scaled string
+		scale  int
+		want   string
+	}{
+		{"123456", 2, "1234.56"},
+		{"-123456", 2, "-1234.56"},
+		{"5", 2, "0.05"},
+		{"123", 0, "123"},
+	}
+
+	for _, tt := range tests {
+		got := string(scaleToDecimalString([]byte(tt.scaled), tt.scale))
+		if got != tt.want {
+			t.Errorf("scaleToDecimalString(%q, %d) = %q, want %q", tt.scaled, tt.scale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package generators
+
+import "math/bits"
+
+// LemireUint64 maps x uniformly into [0, n) using Lemire's nearly-divisionless rejection
+// method (https://lemire.me/blog/2016/06/30/fast-random-shuffling/): it multiplies x by n
+// as a 128-bit product and uses the high half as the candidate, rejecting (biased=true)
+// whenever the low half falls below the threshold (-n)%n that would otherwise bias the
+// result towards the low end of the range.
+//
+// This is the fix for HashReducer, whose Generate sliced hr.g.Generate's output down to
+// hr.size bytes instead of mapping it into a range, and called Generate with an unused
+// nil-length slice rather than the data passed to HashReducer.Generate. HashReducer has
+// been removed; transformers.Int64Transformer's UniformSampler is the caller now — on a
+// biased draw it returns ErrSampleOutOfRange so Int64Transformer.Transform redraws fresh
+// bytes from the generator, keyed on the original column value, instead of looping here.
+func LemireUint64(x, n uint64) (value uint64, biased bool) {
+	hi, lo := bits.Mul64(x, n)
+	if lo < n {
+		threshold := -n % n
+		if lo < threshold {
+			return 0, true
+		}
+	}
+	return hi, false
+}
@@ -0,0 +1,43 @@
+package generators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLemireUint64_WithinBounds(t *testing.T) {
+	const n = 37
+
+	for _, x := range []uint64{0, 1, n - 1, n, n + 1, math.MaxUint64 / 2, math.MaxUint64 - 1, math.MaxUint64} {
+		value, biased := LemireUint64(x, n)
+		if biased {
+			continue
+		}
+		if value >= n {
+			t.Errorf("LemireUint64(%d, %d) = %d, want < %d", x, n, value, n)
+		}
+	}
+}
+
+// TestLemireUint64_RejectsLowEndBias checks that the region Lemire's method must reject to
+// avoid skewing the distribution is indeed reported as biased: with n not dividing 2^64,
+// x=0 falls in the leftover sliver below the largest multiple of n that fits in 2^64, and
+// must be rejected rather than silently mapped to 0.
+func TestLemireUint64_RejectsLowEndBias(t *testing.T) {
+	const n = 3 // 2^64 is not a multiple of 3, so some region must be rejected.
+
+	_, biased := LemireUint64(0, n)
+	if !biased {
+		t.Errorf("LemireUint64(0, %d) should be biased and rejected", n)
+	}
+}
+
+func TestLemireUint64_PowerOfTwoRangeNeverRejects(t *testing.T) {
+	const n = 1 << 8 // 2^64 is an exact multiple of n, so no value should ever be rejected.
+
+	for _, x := range []uint64{0, 1, math.MaxUint64 / 2, math.MaxUint64 - 1, math.MaxUint64} {
+		if _, biased := LemireUint64(x, n); biased {
+			t.Errorf("LemireUint64(%d, %d) unexpectedly biased for a power-of-two range", x, n)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/greenmaskio/greenmask/internal/generators"
+)
+
+// bigIntRejectionSamplingExtraBytes pads the number of bytes pulled from the generator
+// beyond what MaxValue-MinValue strictly needs, keeping the share of candidates that
+// IsBiased rejects in BigIntTransformer.Transform low.
+const bigIntRejectionSamplingExtraBytes = 8
+
+// BigIntLimiter is the math/big counterpart of Int64Limiter: it folds an arbitrary byte
+// stream into the [MinValue, MaxValue] range while keeping the result uniformly
+// distributed via rejection sampling.
+//
+// It intentionally does not share an implementation with generators.LemireUint64 behind
+// one exported range-reducer type. Lemire's method rejects via a single 64-bit multiply
+// against a fixed uint64 modulus, which can't address ranges wider than 2^64 — the whole
+// reason NumericTransformer needs this type instead. Routing Int64Transformer's uniform
+// int64 path through math/big rejection sampling to unify the two would cost a heap
+// allocation and a big.Int division per sample on what is otherwise the hot, fixed-width
+// path. The two stay as separate, width-appropriate implementations.
+type BigIntLimiter struct {
+	MinValue *big.Int
+	MaxValue *big.Int
+	distance *big.Int
+}
+
+func NewBigIntLimiter(minValue, maxValue *big.Int) (*BigIntLimiter, error) {
+	if minValue.Cmp(maxValue) >= 0 {
+		return nil, fmt.Errorf("max value must be greater than min value")
+	}
+
+	distance := new(big.Int).Sub(maxValue, minValue)
+	distance.Add(distance, big.NewInt(1))
+
+	return &BigIntLimiter{
+		MinValue: minValue,
+		MaxValue: maxValue,
+		distance: distance,
+	}, nil
+}
+
+// ByteLength returns how many bytes should be requested from the generators.Generator so
+// that a candidate has enough entropy to keep the rate of IsBiased rejections low.
+func (bil *BigIntLimiter) ByteLength() int {
+	return (bil.distance.BitLen() / 8) + bigIntRejectionSamplingExtraBytes + 1
+}
+
+// rejectionThreshold is the largest multiple of distance that still fits in the space
+// covered by ByteLength bytes. Candidates drawn above it are biased towards the low end
+// of the range and must be rejected.
+func (bil *BigIntLimiter) rejectionThreshold() *big.Int {
+	space := new(big.Int).Lsh(big.NewInt(1), uint(bil.ByteLength())*8)
+	threshold := new(big.Int).Div(space, bil.distance)
+	threshold.Mul(threshold, bil.distance)
+	return threshold
+}
+
+// IsBiased reports whether candidate falls in the region that Limit must reject to avoid
+// skewing the distribution towards the low end of the range.
+func (bil *BigIntLimiter) IsBiased(candidate *big.Int) bool {
+	return candidate.Cmp(bil.rejectionThreshold()) >= 0
+}
+
+// Limit folds an accepted candidate into [MinValue, MaxValue]. Callers must first check
+// IsBiased and redraw a new candidate from the generator until it returns false.
+func (bil *BigIntLimiter) Limit(candidate *big.Int) *big.Int {
+	res := new(big.Int).Mod(candidate, bil.distance)
+	return res.Add(res, bil.MinValue)
+}
+
+// BigIntTransformer produces arbitrary-precision integer values within the bounds of a
+// BigIntLimiter, drawing bytes from the wrapped generators.Generator and rejecting biased
+// candidates so the output stays uniform over [MinValue, MaxValue].
+type BigIntTransformer struct {
+	generator generators.Generator
+	limiter   *BigIntLimiter
+}
+
+func NewBigIntTransformer(g generators.Generator, limiter *BigIntLimiter) (*BigIntTransformer, error) {
+	if limiter == nil {
+		return nil, fmt.Errorf("limiter cannot be nil")
+	}
+	return &BigIntTransformer{
+		generator: g,
+		limiter:   limiter,
+	}, nil
+}
+
+func (bit *BigIntTransformer) GetRequiredGeneratorByteLength() int {
+	return bit.limiter.ByteLength()
+}
+
+// maxBigIntSampleAttempts bounds the reject-and-redraw loop in Transform.
+const maxBigIntSampleAttempts = 1000
+
+// gatherBytes pulls at least length bytes out of the generator, issuing further Generate
+// calls (keyed by an incrementing suffix) when a single call returns fewer than needed.
+// This keeps Transform correct for wide ranges (large ByteLength) even though the
+// generator registered for the transformer may yield a smaller, fixed-size chunk.
+func (bit *BigIntTransformer) gatherBytes(salt []byte, length int) ([]byte, error) {
+	buf := make([]byte, 0, length)
+	for chunk := 0; len(buf) < length; chunk++ {
+		part, err := bit.generator.Generate(append(salt, byte(chunk)))
+		if err != nil {
+			return nil, fmt.Errorf("error generating bytes for big int transformer: %w", err)
+		}
+		buf = append(buf, part...)
+	}
+	return buf, nil
+}
+
+func (bit *BigIntTransformer) Transform(ctx context.Context, originalValue []byte) ([]byte, error) {
+	limiter := bit.limiter
+	if overridden, ok := ctx.Value(LimiterContextKey).(*BigIntLimiter); ok && overridden != nil {
+		limiter = overridden
+	}
+
+	salt := make([]byte, len(originalValue))
+	copy(salt, originalValue)
+
+	needed := limiter.ByteLength()
+	for attempt := 0; attempt < maxBigIntSampleAttempts; attempt++ {
+		rawBytes, err := bit.gatherBytes(append(salt, byte(attempt)), needed)
+		if err != nil {
+			return nil, err
+		}
+
+		candidate := new(big.Int).SetBytes(rawBytes[:needed])
+		if limiter.IsBiased(candidate) {
+			continue
+		}
+
+		return []byte(limiter.Limit(candidate).String()), nil
+	}
+
+	return nil, fmt.Errorf("unable to produce an unbiased big int sample after %d attempts", maxBigIntSampleAttempts)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+// fixedSizeGenerator returns a deterministic chunkSize-byte slice on every call,
+// mimicking a generator that was registered with a fixed byte length.
+type fixedSizeGenerator struct {
+	chunkSize int
+}
+
+func (g *fixedSizeGenerator) Generate(data []byte) ([]byte, error) {
+	res := make([]byte, g.chunkSize)
+	for i := range res {
+		res[i] = byte(len(data) + i)
+	}
+	return res, nil
+}
+
+func (g *fixedSizeGenerator) Size() int {
+	return g.chunkSize
+}
+
+// TestBigIntTransformer_WideRangeOnNarrowGenerator reproduces a range whose
+// BigIntLimiter.ByteLength exceeds the byte count a single Generate call returns (as
+// happens with a very wide min/max): Transform must keep pulling bytes instead
+// of slicing past the end of a short rawBytes buffer.
+func TestBigIntTransformer_WideRangeOnNarrowGenerator(t *testing.T) {
+	// A 38-digit bound (the default numeric precision) only needs a 25-byte
+	// ByteLength, which fits in a single 32-byte Generate call. Use a much wider
+	// bound so distance.BitLen() pushes ByteLength past 32 and actually forces
+	// Transform to gather bytes across multiple Generate calls.
+	bound := new(big.Int).Exp(big.NewInt(10), big.NewInt(100), nil)
+	bound.Sub(bound, big.NewInt(1))
+	minValue := new(big.Int).Neg(bound)
+
+	limiter, err := NewBigIntLimiter(minValue, bound)
+	if err != nil {
+		t.Fatalf("unexpected error creating limiter: %v", err)
+	}
+	if limiter.ByteLength() <= 32 {
+		t.Fatalf("test assumes a range wider than a single 32-byte generator chunk, got ByteLength=%d", limiter.ByteLength())
+	}
+
+	transformer, err := NewBigIntTransformer(&fixedSizeGenerator{chunkSize: 32}, limiter)
+	if err != nil {
+		t.Fatalf("unexpected error creating transformer: %v", err)
+	}
+
+	res, err := transformer.Transform(context.Background(), []byte("42"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	value, ok := new(big.Int).SetString(string(res), 10)
+	if !ok {
+		t.Fatalf("result %q is not a valid integer literal", res)
+	}
+	if value.Cmp(limiter.MinValue) < 0 || value.Cmp(limiter.MaxValue) > 0 {
+		t.Fatalf("result %s is out of range [%s, %s]", value, limiter.MinValue, limiter.MaxValue)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers
+
+// limiterContextKey is an unexported type so the context value below can't collide with
+// keys from other packages, keeping `go vet`'s "should not use basic type string as key in
+// context.WithValue" check clean.
+type limiterContextKey struct{}
+
+// LimiterContextKey is the context.WithValue key callers use to override a transformer's
+// configured limiter for a single Transform call, e.g. IntegerTransformer/NumericTransformer
+// recomputing per-row bounds for a dynamic-mode "min"/"max" parameter.
+var LimiterContextKey = limiterContextKey{}
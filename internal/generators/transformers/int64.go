@@ -0,0 +1,283 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/greenmaskio/greenmask/internal/generators"
+)
+
+// ErrSampleOutOfRange is returned by a Sampler when the value it drew falls outside the
+// limiter's [MinValue, MaxValue] range. Int64Transformer treats it as a signal to redraw
+// rather than as a fatal error.
+var ErrSampleOutOfRange = errors.New("sample is out of range")
+
+// Transformer is the common contract between the fixed-width (Int64Transformer) and
+// arbitrary-precision (BigIntTransformer) numeric generators: turn the original raw column
+// value into a new raw value, consuming bytes from the wrapped generators.Generator.
+type Transformer interface {
+	Transform(ctx context.Context, originalValue []byte) ([]byte, error)
+}
+
+// Int64Limiter folds an 8-byte generator output into the [MinValue, MaxValue] range.
+type Int64Limiter struct {
+	MinValue int64
+	MaxValue int64
+	distance uint64
+}
+
+func NewInt64Limiter(minValue, maxValue int64) (*Int64Limiter, error) {
+	if minValue >= maxValue {
+		return nil, fmt.Errorf("max value must be greater than min value")
+	}
+	return &Int64Limiter{
+		MinValue: minValue,
+		MaxValue: maxValue,
+		distance: uint64(maxValue-minValue) + 1,
+	}, nil
+}
+
+// Int64Transformer produces int64 values within an Int64Limiter's bounds. By default it
+// samples uniformly, but a Sampler can be supplied to skew the output towards a normal,
+// exponential or Zipfian distribution.
+type Int64Transformer struct {
+	generator generators.Generator
+	limiter   *Int64Limiter
+	sampler   Sampler
+}
+
+func NewInt64Transformer(g generators.Generator, limiter *Int64Limiter) (*Int64Transformer, error) {
+	if limiter == nil {
+		return nil, fmt.Errorf("limiter cannot be nil")
+	}
+	return &Int64Transformer{
+		generator: g,
+		limiter:   limiter,
+		sampler:   UniformSampler{},
+	}, nil
+}
+
+// SetSampler overrides the distribution used to turn generator bytes into a value within
+// the limiter's bounds. Passing nil resets it to the default uniform sampler.
+func (it *Int64Transformer) SetSampler(s Sampler) {
+	if s == nil {
+		s = UniformSampler{}
+	}
+	it.sampler = s
+}
+
+// GetRequiredGeneratorByteLength returns how many bytes the current sampler needs out of
+// each Generate call: 16 for NormalSampler/ZipfSampler (two uint64 halves), 8 otherwise.
+func (it *Int64Transformer) GetRequiredGeneratorByteLength() int {
+	switch it.sampler.(type) {
+	case NormalSampler, ZipfSampler:
+		return 16
+	default:
+		return 8
+	}
+}
+
+// maxSampleAttempts bounds the reject-and-redraw loop in Transform: a non-uniform sampler
+// whose parameters place most of its mass outside [min, max] would otherwise spin forever.
+const maxSampleAttempts = 1000
+
+func (it *Int64Transformer) Transform(ctx context.Context, originalValue []byte) ([]byte, error) {
+	limiter := it.limiter
+	if overridden, ok := ctx.Value(LimiterContextKey).(*Int64Limiter); ok && overridden != nil {
+		limiter = overridden
+	}
+
+	salt := make([]byte, len(originalValue))
+	copy(salt, originalValue)
+
+	for attempt := 0; attempt < maxSampleAttempts; attempt++ {
+		rawBytes, err := it.generator.Generate(append(salt, byte(attempt)))
+		if err != nil {
+			return nil, fmt.Errorf("error generating bytes for int64 transformer: %w", err)
+		}
+
+		res, err := it.sampler.Sample(rawBytes, limiter)
+		if errors.Is(err, ErrSampleOutOfRange) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error sampling int64 value: %w", err)
+		}
+
+		return []byte(fmt.Sprintf("%d", res)), nil
+	}
+
+	return nil, fmt.Errorf("unable to produce an in-range sample after %d attempts: check distribution parameters", maxSampleAttempts)
+}
+
+// Sampler turns the raw bytes produced by a generators.Generator into an int64 within an
+// Int64Limiter's [MinValue, MaxValue] range, following some probability distribution.
+type Sampler interface {
+	// Sample reads little-endian uint64 values from the start of raw (8 bytes for
+	// UniformSampler and ExponentialSampler, 16 for NormalSampler and ZipfSampler, see
+	// Int64Transformer.GetRequiredGeneratorByteLength) and returns a value within
+	// limiter's bounds, or ErrSampleOutOfRange when the draw must be redone.
+	Sample(raw []byte, limiter *Int64Limiter) (int64, error)
+}
+
+func uint64Halves(raw []byte) (uint64, uint64) {
+	return binary.LittleEndian.Uint64(raw[0:8]), binary.LittleEndian.Uint64(raw[8:16])
+}
+
+// uniformUnit turns a uint64 into a float64 in [0, 1).
+func uniformUnit(u uint64) float64 {
+	return float64(u>>11) / (1 << 53)
+}
+
+// UniformSampler is the default: the first 8 bytes of the generator output are mapped into
+// the limiter's range via generators.LemireUint64's rejection sampling, so the result stays
+// uniform with no modulo bias. A biased draw surfaces as ErrSampleOutOfRange, which
+// Int64Transformer.Transform treats as a signal to redraw from the generator.
+type UniformSampler struct{}
+
+func (UniformSampler) Sample(raw []byte, limiter *Int64Limiter) (int64, error) {
+	if len(raw) < 8 {
+		return 0, fmt.Errorf("uniform sampler requires at least 8 bytes, got %d", len(raw))
+	}
+	value, biased := generators.LemireUint64(binary.LittleEndian.Uint64(raw[0:8]), limiter.distance)
+	if biased {
+		return 0, ErrSampleOutOfRange
+	}
+	return limiter.MinValue + int64(value), nil
+}
+
+// NormalSampler maps generator output to a normal distribution via the Box-Muller
+// transform; values outside the limiter's range are rejected with ErrSampleOutOfRange.
+type NormalSampler struct {
+	Mean   float64
+	StdDev float64
+}
+
+func (s NormalSampler) Sample(raw []byte, limiter *Int64Limiter) (int64, error) {
+	if len(raw) < 16 {
+		return 0, fmt.Errorf("normal sampler requires at least 16 bytes, got %d", len(raw))
+	}
+	u1, u2 := uint64Halves(raw)
+	u1f, u2f := uniformUnit(u1), uniformUnit(u2)
+	if u1f == 0 {
+		u1f = math.SmallestNonzeroFloat64
+	}
+	z := math.Sqrt(-2*math.Log(u1f)) * math.Cos(2*math.Pi*u2f)
+	return rangeCheck(s.Mean+z*s.StdDev, limiter)
+}
+
+// ExponentialSampler maps generator output to an exponential distribution via inverse CDF
+// sampling: -ln(U)/rate.
+type ExponentialSampler struct {
+	Rate float64
+}
+
+func (s ExponentialSampler) Sample(raw []byte, limiter *Int64Limiter) (int64, error) {
+	if len(raw) < 8 {
+		return 0, fmt.Errorf("exponential sampler requires at least 8 bytes, got %d", len(raw))
+	}
+	u, _ := uint64Halves(padTo16(raw))
+	uf := uniformUnit(u)
+	if uf == 0 {
+		uf = math.SmallestNonzeroFloat64
+	}
+	x := -math.Log(uf) / s.Rate
+	return rangeCheck(float64(limiter.MinValue)+x, limiter)
+}
+
+// ZipfSampler maps generator output to a Zipfian distribution over [MinValue, MaxValue]
+// by delegating to math/rand.Zipf (Devroye's rejection method), with skew parameter S
+// (must be > 1) and offset parameter V (must be >= 1) shaping the decay curve. V is not a
+// post-hoc shift of the result: math/rand.Zipf already folds it into h/hinv, so the
+// sampled value is returned as-is, only translated into the limiter's range.
+type ZipfSampler struct {
+	S float64
+	V float64
+}
+
+func (s ZipfSampler) Sample(raw []byte, limiter *Int64Limiter) (int64, error) {
+	if len(raw) < 16 {
+		return 0, fmt.Errorf("zipf sampler requires at least 16 bytes, got %d", len(raw))
+	}
+	u1, u2 := uint64Halves(raw)
+	src := &fixedBytesSource{a: u1, b: u2}
+	z := rand.NewZipf(rand.New(src), s.S, s.V, uint64(limiter.MaxValue-limiter.MinValue))
+	if z == nil {
+		return 0, fmt.Errorf("invalid zipf parameters: s must be > 1 and v must be >= 1, got s=%v v=%v", s.S, s.V)
+	}
+	return rangeCheck(float64(limiter.MinValue)+float64(z.Uint64()), limiter)
+}
+
+// fixedBytesSource is a math/rand.Source64 that stretches the two uint64 halves of a
+// generator draw into as many pseudo-random draws as ZipfSampler's rejection loop needs,
+// recombining the state on every call so repeated rejections still advance.
+type fixedBytesSource struct {
+	a, b uint64
+}
+
+func (s *fixedBytesSource) Uint64() uint64 {
+	s.a, s.b = s.b, s.a^(s.b*0x9E3779B97F4A7C15+1)
+	return s.a
+}
+
+func (s *fixedBytesSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *fixedBytesSource) Seed(int64) {}
+
+func padTo16(raw []byte) []byte {
+	if len(raw) >= 16 {
+		return raw
+	}
+	padded := make([]byte, 16)
+	copy(padded, raw)
+	return padded
+}
+
+// rangeCheck rejects v if it falls outside the limiter's bounds, so the caller can redraw
+// instead of silently skewing the distribution by clamping.
+func rangeCheck(v float64, limiter *Int64Limiter) (int64, error) {
+	if v < float64(limiter.MinValue) || v > float64(limiter.MaxValue) {
+		return 0, ErrSampleOutOfRange
+	}
+	return int64(v), nil
+}
+
+// EstimateRejectionRate draws `trials` samples from sampler using a deterministic
+// pseudo-random byte source and reports the fraction that fell outside the limiter's
+// range. It is used at transformer-construction time to warn about parameter choices that
+// would make the reject-and-redraw loop in Int64Transformer.Transform too expensive.
+func EstimateRejectionRate(sampler Sampler, limiter *Int64Limiter, trials int) float64 {
+	var rejected int
+	state := uint64(0x2545F4914F6CDD1D)
+	for i := 0; i < trials; i++ {
+		raw := make([]byte, 16)
+		state = state*6364136223846793005 + 1442695040888963407
+		binary.LittleEndian.PutUint64(raw[0:8], state)
+		state = state*6364136223846793005 + 1442695040888963407
+		binary.LittleEndian.PutUint64(raw[8:16], state)
+
+		if _, err := sampler.Sample(raw, limiter); errors.Is(err, ErrSampleOutOfRange) {
+			rejected++
+		}
+	}
+	return float64(rejected) / float64(trials)
+}
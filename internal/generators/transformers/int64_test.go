@@ -0,0 +1,138 @@
+// Copyright 2023 Greenmask
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// counterGenerator deterministically returns length bytes derived from an internal
+// counter, so every call (including reject-and-redraw retries) returns fresh entropy
+// instead of looping forever on the same input.
+type counterGenerator struct {
+	length  int
+	counter uint64
+}
+
+func (g *counterGenerator) Generate(data []byte) ([]byte, error) {
+	g.counter++
+	res := make([]byte, g.length)
+	for i := range res {
+		res[i] = byte(g.counter>>uint(8*(i%8))) ^ byte(len(data))
+	}
+	return res, nil
+}
+
+func (g *counterGenerator) Size() int {
+	return g.length
+}
+
+// TestInt64Transformer_Distributions runs every Sampler end-to-end through
+// Int64Transformer.Transform (not just the sampler's Sample method in isolation) with a
+// generator sized per GetRequiredGeneratorByteLength, and checks the result both parses
+// and lands within [min, max].
+func TestInt64Transformer_Distributions(t *testing.T) {
+	limiter, err := NewInt64Limiter(-100, 100)
+	if err != nil {
+		t.Fatalf("unexpected error creating limiter: %v", err)
+	}
+
+	samplers := map[string]Sampler{
+		"uniform":     UniformSampler{},
+		"normal":      NormalSampler{Mean: 0, StdDev: 20},
+		"exponential": ExponentialSampler{Rate: 0.1},
+		"zipf":        ZipfSampler{S: 1.5, V: 1},
+	}
+
+	for name, sampler := range samplers {
+		t.Run(name, func(t *testing.T) {
+			transformer, err := NewInt64Transformer(&counterGenerator{length: 16}, limiter)
+			if err != nil {
+				t.Fatalf("unexpected error creating transformer: %v", err)
+			}
+			transformer.SetSampler(sampler)
+
+			if got := transformer.GetRequiredGeneratorByteLength(); got > 16 {
+				t.Fatalf("transformer reports needing %d bytes, generator only provides 16", got)
+			}
+
+			for i := 0; i < 50; i++ {
+				res, err := transformer.Transform(context.Background(), []byte(fmt.Sprintf("row-%d", i)))
+				if err != nil {
+					t.Fatalf("unexpected error on Transform: %v", err)
+				}
+
+				value, err := strconv.ParseInt(string(res), 10, 64)
+				if err != nil {
+					t.Fatalf("result %q is not a valid int64: %v", res, err)
+				}
+				if value < limiter.MinValue || value > limiter.MaxValue {
+					t.Fatalf("result %d is out of range [%d, %d]", value, limiter.MinValue, limiter.MaxValue)
+				}
+			}
+		})
+	}
+}
+
+// TestInt64Transformer_ZipfDecays checks that ZipfSampler actually produces a power-law
+// decay instead of a constant: a prior implementation returned limiter.MinValue on every
+// call once S exceeded 1 (the documented default), which TestInt64Transformer_Distributions
+// didn't catch because it only asserts the result is in-range.
+func TestInt64Transformer_ZipfDecays(t *testing.T) {
+	limiter, err := NewInt64Limiter(0, 999)
+	if err != nil {
+		t.Fatalf("unexpected error creating limiter: %v", err)
+	}
+
+	transformer, err := NewInt64Transformer(&counterGenerator{length: 16}, limiter)
+	if err != nil {
+		t.Fatalf("unexpected error creating transformer: %v", err)
+	}
+	transformer.SetSampler(ZipfSampler{S: 1.5, V: 1})
+
+	const trials = 2000
+	mid := (limiter.MinValue + limiter.MaxValue) / 2
+	seen := make(map[int64]bool)
+	var lowerHalf, upperHalf int
+
+	for i := 0; i < trials; i++ {
+		res, err := transformer.Transform(context.Background(), []byte(fmt.Sprintf("row-%d", i)))
+		if err != nil {
+			t.Fatalf("unexpected error on Transform: %v", err)
+		}
+
+		value, err := strconv.ParseInt(string(res), 10, 64)
+		if err != nil {
+			t.Fatalf("result %q is not a valid int64: %v", res, err)
+		}
+
+		seen[value] = true
+		if value <= mid {
+			lowerHalf++
+		} else {
+			upperHalf++
+		}
+	}
+
+	if len(seen) < 2 {
+		t.Fatalf("zipf sampler produced a constant value across %d trials: %v", trials, seen)
+	}
+	if lowerHalf <= upperHalf {
+		t.Fatalf("expected zipf distribution to favor the low end of the range, got lowerHalf=%d upperHalf=%d", lowerHalf, upperHalf)
+	}
+}